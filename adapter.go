@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"io"
+	"log"
+	"strings"
+)
+
+// depthOfWriterCaller is the number of stack frames between printDepthFields
+// and the code that called the function writing to logWriter (for example
+// the line calling fmt.Fprintln): frame 1 is logWriter.Write, frame 2 is the
+// function calling Write (fmt.Fprintln), frame 3 is that function's caller.
+const depthOfWriterCaller = depthOfDirectCaller - 1
+
+// logWriter is the io.Writer shim backing PackageLogger.Writer/StdLogger: it
+// trims all trailing newlines (log.Logger always appends one) and routes
+// the remaining text through the module at a fixed level.
+type logWriter struct {
+	pl    *PackageLogger
+	level LogLevel
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\n")
+	w.pl.printDepthFields(w.level, msg, nil, depthOfWriterCaller)
+	return len(p), nil
+}
+
+// Writer returns an io.Writer that routes everything written to it through
+// this PackageLogger at the given level, so it can be plugged into anything
+// that only accepts an io.Writer without bypassing rotation, syslog and
+// per-package level gating.
+func (v *PackageLogger) Writer(level LogLevel) io.Writer {
+	return &logWriter{pl: v, level: level}
+}
+
+// StdLogger returns a *log.Logger whose output is redirected into this
+// module at the given level, for interop with APIs that only accept
+// *log.Logger (http.Server.ErrorLog, sql.DB diagnostics, grpclog, ...).
+func (v *PackageLogger) StdLogger(level LogLevel) *log.Logger {
+	return log.New(v.Writer(level), "", 0)
+}
+
+// RedirectStdLog routes the default "log" package's output through this
+// module, so incidental log.Printf calls elsewhere in a program end up
+// rotated/syslogged/level-gated like everything else.
+func RedirectStdLog() {
+	pl := lgr.NewPackageLogger("stdlog", InfoLevel)
+	log.SetOutput(pl.Writer(InfoLevel))
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestWriterAttributesToCaller guards against depthOfWriterCaller
+// regressing: a write through PackageLogger.Writer via fmt.Fprintln must
+// resolve the source location to the line calling fmt.Fprintln, not to
+// logWriter.Write or fmt.Fprintln.
+func TestWriterAttributesToCaller(t *testing.T) {
+	_, capture, pl := newCaptureLogger(InfoLevel)
+	w := pl.Writer(InfoLevel)
+
+	_, wantFile, wantLine, ok := runtime.Caller(0)
+	fmt.Fprintln(w, "marker")
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+
+	if len(capture.msgs) != 1 {
+		t.Fatalf("expected 1 captured message, got %d", len(capture.msgs))
+	}
+	wantPrefix := fmt.Sprintf("%s:%d:", filepath.Base(wantFile), wantLine+1)
+	if !strings.HasPrefix(capture.msgs[0], wantPrefix) {
+		t.Fatalf("Writer write resolved to the wrong frame: got %q, want prefix %q",
+			capture.msgs[0], wantPrefix)
+	}
+}
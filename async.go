@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// DropPolicy controls what happens to a log record when the async buffer is
+// full: either the caller blocks until room is available, or the oldest
+// buffered record is discarded to make room for the new one.
+type DropPolicy int
+
+const (
+	BlockOnFull DropPolicy = iota
+	DropOldest
+)
+
+// logRecord is a log entry queued for delivery by the async pipeline. A
+// record with flushDone set is a control marker: delivering it simply closes
+// the channel to signal a pending Flush.
+type logRecord struct {
+	pl        *PackageLogger
+	record    Record
+	flushDone chan struct{}
+}
+
+// EnableAsync switches the file, syslog and console sinks to asynchronous
+// delivery: print formats each record and hands it to a buffered channel
+// drained by a single background goroutine, so callers no longer block on
+// file/syslog/console I/O. Calling EnableAsync a second time is a no-op.
+func (v *Logger) EnableAsync(bufferSize int) {
+	v.Lock()
+	defer v.Unlock()
+	if v.asyncCh != nil {
+		return
+	}
+	ch := make(chan *logRecord, bufferSize)
+	v.asyncCh = ch
+	v.asyncWG.Add(1)
+	go v.asyncLoop(ch)
+}
+
+func (v *Logger) asyncLoop(ch chan *logRecord) {
+	defer v.asyncWG.Done()
+	for rec := range ch {
+		if rec.flushDone != nil {
+			close(rec.flushDone)
+			continue
+		}
+		rec.pl.deliver(rec)
+	}
+}
+
+// SetAsyncDropPolicy configures how the async pipeline behaves when its
+// buffer is full. It has no effect unless EnableAsync has been called.
+func (v *Logger) SetAsyncDropPolicy(policy DropPolicy) {
+	v.Lock()
+	defer v.Unlock()
+	v.asyncDropPolicy = policy
+}
+
+// DroppedCount returns the number of records discarded by the DropOldest
+// policy since the async pipeline was enabled.
+func (v *Logger) DroppedCount() int64 {
+	return atomic.LoadInt64(&v.asyncDropped)
+}
+
+// enqueue hands rec to the async pipeline, or delivers it synchronously if
+// async mode is not enabled. The RLock is held for the whole check-then-send
+// so that it can never race with Close(), which flips asyncClosed and
+// detaches the channel under the write Lock before closing it - see
+// Logger.Close.
+func (v *Logger) enqueue(rec *logRecord) {
+	v.RLock()
+	ch := v.asyncCh
+	closed := v.asyncClosed
+	policy := v.asyncDropPolicy
+	if ch == nil || closed {
+		v.RUnlock()
+		rec.pl.deliver(rec)
+		return
+	}
+	if policy == DropOldest {
+		select {
+		case ch <- rec:
+		default:
+			select {
+			case <-ch:
+				atomic.AddInt64(&v.asyncDropped, 1)
+			default:
+			}
+			select {
+			case ch <- rec:
+			default:
+				atomic.AddInt64(&v.asyncDropped, 1)
+			}
+		}
+	} else {
+		ch <- rec
+	}
+	v.RUnlock()
+}
+
+// trySendAsync attempts to hand rec to the async channel under the same
+// RLock/closed-flag protocol as enqueue, so Flush can never send on a
+// channel Close is in the middle of tearing down.
+func (v *Logger) trySendAsync(ctx context.Context, rec *logRecord) (sent bool, err error) {
+	v.RLock()
+	defer v.RUnlock()
+	if v.asyncCh == nil || v.asyncClosed {
+		return false, nil
+	}
+	select {
+	case v.asyncCh <- rec:
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// Flush blocks until every record enqueued before the call has been
+// delivered by the async pipeline, or ctx is cancelled. Flush is a no-op
+// when async mode is not enabled.
+func (v *Logger) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	marker := &logRecord{flushDone: done}
+	sent, err := v.trySendAsync(ctx, marker)
+	if err != nil || !sent {
+		return err
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// depthOfDirectCaller is the number of stack frames between
+// printDepthFields and the direct caller of a non-Depth logging method such
+// as Debugf. The exported *Depth variants add their explicit depth argument
+// on top of this so that wrapper libraries can report the location that
+// called *them*.
+const depthOfDirectCaller = 4
+
+// depthOfDepthCaller is the number of stack frames between printDepthFields
+// and the direct caller of a *Depth method such as DebugfDepth. The chain is
+// one frame shorter than the plain (non-Depth) chain because it calls
+// straight into PrintfDepth instead of going through print/Printf first.
+const depthOfDepthCaller = depthOfDirectCaller - 1
+
+// EnableSourceLocation controls whether printDepthFields prepends
+// "file.go:NNN: " to every formatted record, using the caller location
+// resolved via runtime.Caller.
+func (v *Logger) EnableSourceLocation(enable bool) {
+	v.Lock()
+	defer v.Unlock()
+	v.sourceLocation = enable
+}
+
+func (v *Logger) GetSourceLocation() bool {
+	v.RLock()
+	defer v.RUnlock()
+	return v.sourceLocation
+}
+
+// SetBacktraceAt configures a set of source locations ("file.go:NNN") that,
+// when a log statement fires at that exact location, get a formatted
+// goroutine stack appended to that single record - a glog-style debugging
+// aid. Passing no locations clears the set.
+func (v *Logger) SetBacktraceAt(locations ...string) error {
+	set := make(map[string]bool, len(locations))
+	for _, loc := range locations {
+		idx := strings.LastIndex(loc, ":")
+		if idx < 0 {
+			return fmt.Errorf("backtrace location %q is not in file.go:NNN form", loc)
+		}
+		if _, err := strconv.Atoi(loc[idx+1:]); err != nil {
+			return fmt.Errorf("backtrace location %q has invalid line number: %v", loc, err)
+		}
+		set[loc] = true
+	}
+	v.Lock()
+	defer v.Unlock()
+	v.backtraceAt = set
+	return nil
+}
+
+func (v *Logger) matchesBacktraceAt(file string, line int) bool {
+	v.RLock()
+	defer v.RUnlock()
+	if len(v.backtraceAt) == 0 {
+		return false
+	}
+	return v.backtraceAt[fmt.Sprintf("%s:%d", filepath.Base(file), line)]
+}
+
+// printDepthFields is the common entry point for every PackageLogger logging
+// method, with an optional set of structured fields to attach to the
+// record: depth is the number of stack frames to skip (via runtime.Caller)
+// to reach the call site that should be reported/matched against
+// SetBacktraceAt. Callers with no fields (print, PrintfDepth, logWriter)
+// pass nil; Entry.print passes its attached fields. It is called directly,
+// rather than through a thin printDepth(level, msg, depth) wrapper, so that
+// every caller's depth constant counts frames to the same fixed point.
+func (v *PackageLogger) printDepthFields(level LogLevel, msg string, fields map[string]interface{}, depth int) {
+	lvl := v.GetLogLevel()
+	if lvl < level {
+		return
+	}
+	if _, file, line, ok := runtime.Caller(depth); ok {
+		if v.parent.GetSourceLocation() {
+			msg = fmt.Sprintf("%s:%d: %s", filepath.Base(file), line, msg)
+		}
+		if v.parent.matchesBacktraceAt(file, line) {
+			buf := make([]byte, 1<<16)
+			n := runtime.Stack(buf, false)
+			msg = fmt.Sprintf("%s\n%s", msg, buf[:n])
+		}
+	}
+	record := Record{Time: time.Now(), Level: level, App: v.appName(),
+		Package: v.packageName, Msg: msg, Fields: fields}
+	v.dispatch(record)
+}
+
+// PrintfDepth is like Printf but reports the caller depth frames above its
+// own caller, for use by wrapper libraries.
+func (v *PackageLogger) PrintfDepth(depth int, level LogLevel, format string, args ...interface{}) {
+	lvl := v.GetLogLevel()
+	if lvl >= level {
+		msg := spew.Sprintf(format, args...)
+		v.printDepthFields(level, msg, nil, depthOfDepthCaller+depth)
+	}
+}
+
+func (v *PackageLogger) DebugfDepth(depth int, format string, args ...interface{}) {
+	v.PrintfDepth(depth, DebugLevel, format, args...)
+}
+
+func (v *PackageLogger) InfofDepth(depth int, format string, args ...interface{}) {
+	v.PrintfDepth(depth, InfoLevel, format, args...)
+}
+
+func (v *PackageLogger) WarnfDepth(depth int, format string, args ...interface{}) {
+	v.PrintfDepth(depth, WarnLevel, format, args...)
+}
+
+func (v *PackageLogger) ErrorfDepth(depth int, format string, args ...interface{}) {
+	v.PrintfDepth(depth, ErrorLevel, format, args...)
+}
+
+func (v *PackageLogger) PanicfDepth(depth int, format string, args ...interface{}) {
+	v.PrintfDepth(depth, PanicLevel, format, args...)
+}
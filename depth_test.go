@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type captureSink struct {
+	msgs []string
+}
+
+func (s *captureSink) Name() string       { return "capture" }
+func (s *captureSink) Levels() []LogLevel { return allLevels }
+func (s *captureSink) Close() error       { return nil }
+func (s *captureSink) Write(record Record) error {
+	s.msgs = append(s.msgs, record.Msg)
+	return nil
+}
+
+func newCaptureLogger(level LogLevel) (*Logger, *captureSink, *PackageLogger) {
+	l := NewLogger()
+	l.EnableSourceLocation(true)
+	capture := &captureSink{}
+	l.sinks = []Sink{capture}
+	pl := l.NewPackageLogger("depthtest", level)
+	return l, capture, pl
+}
+
+// TestDebugfDepthResolvesDirectCaller guards against the depthOfDepthCaller
+// arithmetic regressing: DebugfDepth(0, ...) must resolve to its own call
+// site, not three frames too far into the runtime package.
+func TestDebugfDepthResolvesDirectCaller(t *testing.T) {
+	_, capture, pl := newCaptureLogger(DebugLevel)
+
+	_, wantFile, wantLine, ok := runtime.Caller(0)
+	pl.DebugfDepth(0, "marker")
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+
+	if len(capture.msgs) != 1 {
+		t.Fatalf("expected 1 captured message, got %d", len(capture.msgs))
+	}
+	wantPrefix := fmt.Sprintf("%s:%d:", filepath.Base(wantFile), wantLine+1)
+	if !strings.HasPrefix(capture.msgs[0], wantPrefix) {
+		t.Fatalf("DebugfDepth(0, ...) resolved to the wrong frame: got %q, want prefix %q",
+			capture.msgs[0], wantPrefix)
+	}
+}
+
+func wrapperLog(pl *PackageLogger, msg string) {
+	pl.InfofDepth(1, "%s", msg)
+}
+
+// TestInfofDepthAttributesToWrapperCaller exercises the wrapper-library use
+// case the *Depth family exists for: InfofDepth(1, ...) called from inside
+// wrapperLog must attribute the log line to wrapperLog's caller, not to
+// wrapperLog itself.
+func TestInfofDepthAttributesToWrapperCaller(t *testing.T) {
+	_, capture, pl := newCaptureLogger(InfoLevel)
+
+	_, wantFile, wantLine, ok := runtime.Caller(0)
+	wrapperLog(pl, "marker")
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+
+	if len(capture.msgs) != 1 {
+		t.Fatalf("expected 1 captured message, got %d", len(capture.msgs))
+	}
+	wantPrefix := fmt.Sprintf("%s:%d:", filepath.Base(wantFile), wantLine+1)
+	if !strings.HasPrefix(capture.msgs[0], wantPrefix) {
+		t.Fatalf("InfofDepth(1, ...) resolved to the wrong frame: got %q, want prefix %q",
+			capture.msgs[0], wantPrefix)
+	}
+}
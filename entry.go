@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"github.com/davecgh/go-spew/spew"
+)
+
+// Entry carries a fixed set of structured fields across one or more log
+// calls. It is created via PackageLogger.WithFields and mirrors the
+// Debug/Info/Warn/Error/Panic API of PackageLogger itself; the fields are
+// only surfaced by sinks that understand them (e.g. JSONSink) and are
+// otherwise ignored by the plain-text console/file output.
+type Entry struct {
+	pl     *PackageLogger
+	fields map[string]interface{}
+}
+
+// WithFields returns an Entry that attaches fields to every record logged
+// through it.
+func (v *PackageLogger) WithFields(fields map[string]interface{}) *Entry {
+	return &Entry{pl: v, fields: fields}
+}
+
+func (e *Entry) print(level LogLevel, msg string) {
+	e.pl.printDepthFields(level, msg, e.fields, depthOfDirectCaller)
+}
+
+func (e *Entry) Printf(level LogLevel, format string, args ...interface{}) {
+	lvl := e.pl.GetLogLevel()
+	if lvl >= level {
+		msg := spew.Sprintf(format, args...)
+		e.print(level, msg)
+	}
+}
+
+func (e *Entry) Print(level LogLevel, args ...interface{}) {
+	lvl := e.pl.GetLogLevel()
+	if lvl >= level {
+		msg := spew.Sprint(args...)
+		e.print(level, msg)
+	}
+}
+
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.Printf(DebugLevel, format, args...)
+}
+
+func (e *Entry) Debug(args ...interface{}) {
+	e.Print(DebugLevel, args...)
+}
+
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.Printf(InfoLevel, format, args...)
+}
+
+func (e *Entry) Info(args ...interface{}) {
+	e.Print(InfoLevel, args...)
+}
+
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.Printf(WarnLevel, format, args...)
+}
+
+func (e *Entry) Warn(args ...interface{}) {
+	e.Print(WarnLevel, args...)
+}
+
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.Printf(ErrorLevel, format, args...)
+}
+
+func (e *Entry) Error(args ...interface{}) {
+	e.Print(ErrorLevel, args...)
+}
+
+func (e *Entry) Panicf(format string, args ...interface{}) {
+	e.Printf(PanicLevel, format, args...)
+}
+
+func (e *Entry) Panic(args ...interface{}) {
+	e.Print(PanicLevel, args...)
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+const logTimeFormat = "2006-01-02 15:04:05.000"
+
+const ansiReset = "\x1b[0m"
+
+// levelColor returns the ANSI color escape used to highlight level in a
+// colored console line, or "" if the level isn't colorized.
+func levelColor(level LogLevel) string {
+	switch level {
+	case PanicLevel, ErrorLevel:
+		return "\x1b[31m"
+	case WarnLevel:
+		return "\x1b[33m"
+	case InfoLevel:
+		return "\x1b[32m"
+	case DebugLevel:
+		return "\x1b[36m"
+	default:
+		return ""
+	}
+}
+
+// levelStr renders level padded to the fixed width implied by levelFormat
+// (ShortLevelLen/LongLevelLen), optionally wrapped in an ANSI color.
+func levelStr(colored bool, level LogLevel, levelFormat LevelFormat) string {
+	var s string
+	width := ShortLevelLen
+	if levelFormat == LevelLong {
+		s = level.LongStr()
+		width = LongLevelLen
+	} else {
+		s = level.ShortStr()
+	}
+	s = fmt.Sprintf("%-*s", width, s)
+	if colored {
+		if c := levelColor(level); c != "" {
+			s = c + s + ansiReset
+		}
+	}
+	return s
+}
+
+// packageStr pads or truncates packageName to printLen characters; a
+// negative printLen leaves packageName untouched.
+func packageStr(packageName string, printLen int) string {
+	if printLen < 0 {
+		return packageName
+	}
+	if len(packageName) > printLen {
+		return packageName[:printLen]
+	}
+	return fmt.Sprintf("%-*s", printLen, packageName)
+}
+
+// fmtStr renders a single log line. The components available to layout via
+// Sprintf's explicit argument indexes are:
+//
+//	%[1] - timestamp
+//	%[2] - application name
+//	%[3] - level (colored when colored is true)
+//	%[4] - package name (padded/truncated to packagePrintLen)
+//	%[5] - message
+func fmtStr(colored bool, level LogLevel, levelFormat LevelFormat, appName string,
+	packageName string, packagePrintLen int, msg string, layout string) string {
+	ts := time.Now().Format(logTimeFormat)
+	return fmt.Sprintf(layout, ts, appName, levelStr(colored, level, levelFormat),
+		packageStr(packageName, packagePrintLen), msg)
+}
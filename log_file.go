@@ -0,0 +1,255 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+const rotatedFileDateFormat = "2006-01-02"
+
+// LogFile manages the single on-disk file a Logger writes to: tracking its
+// size, rotating it out by size (SetRotateParams) and/or by age
+// (SetTimeRotation), gzip-compressing rotated files in the background, and
+// pruning rotated files once they exceed rotateMaxCount or rotateMaxAge.
+// Rotated files are named "<base>.YYYY-MM-DD[.N][.gz]".
+type LogFile struct {
+	sync.Mutex
+	logger       *Logger
+	Path         string
+	size         int64
+	lastRotateAt time.Time
+}
+
+func (lf *LogFile) Close() error {
+	return nil
+}
+
+func (lf *LogFile) ensureSizeLocked() error {
+	if lf.size >= 0 {
+		return nil
+	}
+	fi, err := os.Stat(lf.Path)
+	if os.IsNotExist(err) {
+		lf.size = 0
+		return nil
+	} else if err != nil {
+		return err
+	}
+	lf.size = fi.Size()
+	return nil
+}
+
+// writeToFile appends data to the log file, rotating and pruning first if
+// the size or time thresholds configured on the owning Logger are exceeded.
+func (lf *LogFile) writeToFile(data string, rotateMaxSize int64, rotateMaxCount int) error {
+	lf.Lock()
+	defer lf.Unlock()
+	if err := lf.ensureSizeLocked(); err != nil {
+		return err
+	}
+	if err := lf.rotateIfNeededLocked(rotateMaxSize, rotateMaxCount); err != nil {
+		return err
+	}
+	return lf.appendLocked(data)
+}
+
+func (lf *LogFile) rotateIfNeededLocked(rotateMaxSize int64, rotateMaxCount int) error {
+	if lf.lastRotateAt.IsZero() {
+		lf.lastRotateAt = time.Now()
+	}
+	interval := lf.logger.GetRotateInterval()
+	needTime := interval > 0 && time.Since(lf.lastRotateAt) >= interval
+	needSize := rotateMaxSize > 0 && lf.size > rotateMaxSize
+	if !needTime && !needSize {
+		return nil
+	}
+	if err := lf.rotateLocked(); err != nil {
+		return err
+	}
+	lf.lastRotateAt = time.Now()
+	return lf.pruneLocked(rotateMaxCount)
+}
+
+// rotateLocked renames the current log file to its dated name and kicks off
+// background gzip compression of the rotated file.
+func (lf *LogFile) rotateLocked() error {
+	date := time.Now().Format(rotatedFileDateFormat)
+	target := fmt.Sprintf("%s.%s", lf.Path, date)
+	for index := 1; ; index++ {
+		_, errPlain := os.Stat(target)
+		_, errGz := os.Stat(target + ".gz")
+		if os.IsNotExist(errPlain) && os.IsNotExist(errGz) {
+			break
+		}
+		target = fmt.Sprintf("%s.%s.%d", lf.Path, date, index)
+	}
+	if err := os.Rename(lf.Path, target); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	lf.size = 0
+	level := lf.logger.GetGzipLevel()
+	go compressRotatedFile(lf.logger, target, level)
+	return nil
+}
+
+func compressRotatedFile(logger *Logger, filePath string, level int) {
+	src, err := os.Open(filePath)
+	if err != nil {
+		logger.log.Print(spew.Errorf("Failed to open rotated log %q for compression: %v\n", filePath, err))
+		return
+	}
+	defer src.Close()
+
+	gzPath := filePath + ".gz"
+	dst, err := os.Create(gzPath)
+	if err != nil {
+		logger.log.Print(spew.Errorf("Failed to create compressed log %q: %v\n", gzPath, err))
+		return
+	}
+	defer dst.Close()
+
+	gw, err := gzip.NewWriterLevel(dst, level)
+	if err != nil {
+		logger.log.Print(spew.Errorf("Failed to start gzip writer for %q: %v\n", gzPath, err))
+		return
+	}
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		os.Remove(gzPath)
+		logger.log.Print(spew.Errorf("Failed to compress rotated log %q: %v\n", filePath, err))
+		return
+	}
+	if err := gw.Close(); err != nil {
+		os.Remove(gzPath)
+		logger.log.Print(spew.Errorf("Failed to finish compressing rotated log %q: %v\n", filePath, err))
+		return
+	}
+	src.Close()
+	os.Remove(filePath)
+}
+
+func (lf *LogFile) appendLocked(data string) error {
+	file, err := os.OpenFile(lf.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0660)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	n, err := io.WriteString(file, data)
+	if err != nil {
+		lf.size = -1
+		return err
+	}
+	lf.size += int64(n)
+	return nil
+}
+
+// rotatedLogFile describes a rotated (and possibly gzip-compressed) log file
+// discovered on disk.
+type rotatedLogFile struct {
+	fullPath string
+	date     time.Time
+	index    int
+}
+
+type byRotatedRecency []rotatedLogFile
+
+func (s byRotatedRecency) Len() int      { return len(s) }
+func (s byRotatedRecency) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byRotatedRecency) Less(i, j int) bool {
+	if !s[i].date.Equal(s[j].date) {
+		return s[i].date.Before(s[j].date)
+	}
+	return s[i].index < s[j].index
+}
+
+// parseRotatedFileName matches "<base>.YYYY-MM-DD[.N][.gz]" against name,
+// where base is the base name of the active log file.
+func parseRotatedFileName(base, name string) (rotatedLogFile, bool) {
+	if !strings.HasPrefix(name, base+".") {
+		return rotatedLogFile{}, false
+	}
+	suffix := strings.TrimPrefix(name, base+".")
+	suffix = strings.TrimSuffix(suffix, ".gz")
+	parts := strings.SplitN(suffix, ".", 2)
+	date, err := time.Parse(rotatedFileDateFormat, parts[0])
+	if err != nil {
+		return rotatedLogFile{}, false
+	}
+	index := 0
+	if len(parts) == 2 {
+		index, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return rotatedLogFile{}, false
+		}
+	}
+	return rotatedLogFile{date: date, index: index}, true
+}
+
+func (lf *LogFile) getRotatedFileList() ([]rotatedLogFile, error) {
+	dir := path.Dir(lf.Path)
+	base := path.Base(lf.Path)
+	var list []rotatedLogFile
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if item, ok := parseRotatedFileName(base, entry.Name()); ok {
+			item.fullPath = filepath.Join(dir, entry.Name())
+			list = append(list, item)
+		}
+	}
+	sort.Sort(byRotatedRecency(list))
+	return list, nil
+}
+
+// pruneLocked removes rotated files older than the Logger's rotateMaxAge,
+// then enforces the rotateMaxCount cap on whatever remains.
+func (lf *LogFile) pruneLocked(rotateMaxCount int) error {
+	list, err := lf.getRotatedFileList()
+	if err != nil {
+		return err
+	}
+	maxAge := lf.logger.GetRotateMaxAge()
+	now := time.Now()
+	var kept []rotatedLogFile
+	for _, item := range list {
+		if maxAge > 0 && now.Sub(item.date) > maxAge {
+			if err := os.Remove(item.fullPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		kept = append(kept, item)
+	}
+	if rotateMaxCount > 0 && len(kept) > rotateMaxCount {
+		excess := len(kept) - rotateMaxCount
+		for _, item := range kept[:excess] {
+			if err := os.Remove(item.fullPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -1,14 +1,16 @@
 package logger
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"log"
-	"log/syslog"
 	"os"
 	"path"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/d2r2/go-shell/shell"
 	"github.com/davecgh/go-spew/spew"
@@ -85,6 +87,20 @@ type Logger struct {
 	rotateMaxCount     int
 	appName            string
 	enableSyslog       bool
+	verbosity          int32
+	vmodule            []vmoduleRule
+	vCache             atomic.Value
+	asyncCh            chan *logRecord
+	asyncClosed        bool
+	asyncWG            sync.WaitGroup
+	asyncDropPolicy    DropPolicy
+	asyncDropped       int64
+	rotateInterval     time.Duration
+	rotateMaxAge       time.Duration
+	gzipLevel          int
+	sinks              []Sink
+	sourceLocation     bool
+	backtraceAt        map[string]bool
 }
 
 func NewLogger() *Logger {
@@ -95,14 +111,14 @@ func NewLogger() *Logger {
 		packagePrintLength: 8,
 		rotateMaxSize:      1024 * 1024 * 512,
 		rotateMaxCount:     3,
+		gzipLevel:          gzip.DefaultCompression,
 	}
+	l.sinks = []Sink{NewConsoleSink(l)}
 	return l
 }
 
 func (v *Logger) Close() error {
 	v.Lock()
-	defer v.Unlock()
-
 	for _, pack := range v.packages {
 		pack.Close()
 	}
@@ -111,6 +127,27 @@ func (v *Logger) Close() error {
 	if v.logFile != nil {
 		v.logFile.Close()
 	}
+	// asyncClosed is flipped before Unlock so that any enqueue/Flush call
+	// that acquires the RLock after this point sees it and never touches
+	// ch again - that's what makes closing ch below race-free against
+	// concurrent sends (see enqueue/trySendAsync in async.go).
+	ch := v.asyncCh
+	v.asyncClosed = true
+	v.asyncCh = nil
+	v.Unlock()
+
+	if ch != nil {
+		close(ch)
+		v.asyncWG.Wait()
+	}
+
+	v.Lock()
+	sinks := v.sinks
+	v.sinks = nil
+	v.Unlock()
+	for _, sink := range sinks {
+		sink.Close()
+	}
 	return nil
 }
 
@@ -161,6 +198,10 @@ func (v *Logger) EnableSyslog(enable bool) {
 	v.Lock()
 	defer v.Unlock()
 	v.enableSyslog = enable
+	v.removeSinkLocked("syslog")
+	if enable {
+		v.sinks = append(v.sinks, NewSyslogSink(v))
+	}
 }
 
 func (v *Logger) GetSyslogEnabled() bool {
@@ -191,11 +232,84 @@ func (v *Logger) SetLogFileName(logFilePath string) error {
 	}
 	v.Lock()
 	defer v.Unlock()
-	lf := &LogFile{Path: fp}
+	lf := &LogFile{Path: fp, logger: v, size: -1}
 	v.logFile = lf
+	v.removeSinkLocked("file")
+	v.sinks = append(v.sinks, NewFileSink(v, lf))
 	return nil
 }
 
+// AddSink registers an additional Sink that every subsequent log record is
+// fanned out to, alongside the console/file/syslog sinks managed internally.
+func (v *Logger) AddSink(sink Sink) {
+	v.Lock()
+	defer v.Unlock()
+	v.sinks = append(v.sinks, sink)
+}
+
+// RemoveSink closes and unregisters the sink with the given name, if any.
+func (v *Logger) RemoveSink(name string) {
+	v.Lock()
+	defer v.Unlock()
+	v.removeSinkLocked(name)
+}
+
+func (v *Logger) removeSinkLocked(name string) {
+	var kept []Sink
+	for _, s := range v.sinks {
+		if s.Name() == name {
+			s.Close()
+		} else {
+			kept = append(kept, s)
+		}
+	}
+	v.sinks = kept
+}
+
+func (v *Logger) getSinks() []Sink {
+	v.RLock()
+	defer v.RUnlock()
+	return append([]Sink(nil), v.sinks...)
+}
+
+// SetTimeRotation enables time-based log rotation in addition to the
+// existing size-based rotation: the log file is rotated at least once per
+// interval (e.g. 24*time.Hour for daily rotation), and rotated files older
+// than maxAge are pruned alongside the existing rotateMaxCount cap. A zero
+// interval or maxAge disables the respective check.
+func (v *Logger) SetTimeRotation(interval time.Duration, maxAge time.Duration) {
+	v.Lock()
+	defer v.Unlock()
+	v.rotateInterval = interval
+	v.rotateMaxAge = maxAge
+}
+
+func (v *Logger) GetRotateInterval() time.Duration {
+	v.RLock()
+	defer v.RUnlock()
+	return v.rotateInterval
+}
+
+func (v *Logger) GetRotateMaxAge() time.Duration {
+	v.RLock()
+	defer v.RUnlock()
+	return v.rotateMaxAge
+}
+
+// SetGzipLevel sets the compress/gzip level used to compress rotated log
+// files in the background. Defaults to gzip.DefaultCompression.
+func (v *Logger) SetGzipLevel(level int) {
+	v.Lock()
+	defer v.Unlock()
+	v.gzipLevel = level
+}
+
+func (v *Logger) GetGzipLevel() int {
+	v.RLock()
+	defer v.RUnlock()
+	return v.gzipLevel
+}
+
 func (v *Logger) GetLogFileInfo() *LogFile {
 	v.Lock()
 	defer v.Unlock()
@@ -232,19 +346,9 @@ type PackageLogger struct {
 	parent      *Logger
 	packageName string
 	level       LogLevel
-	syslog      *syslog.Writer
 }
 
 func (v *PackageLogger) Close() error {
-	v.Lock()
-	defer v.Unlock()
-	if v.syslog != nil {
-		err := v.syslog.Close()
-		v.syslog = nil
-		if err != nil {
-			return err
-		}
-	}
 	return nil
 }
 
@@ -260,79 +364,44 @@ func (v *PackageLogger) GetLogLevel() LogLevel {
 	return v.level
 }
 
-func (v *PackageLogger) getSyslog(level LogLevel, levelFormat LevelFormat,
-	appName string) (*syslog.Writer, error) {
-	v.Lock()
-	defer v.Unlock()
-	if v.syslog == nil {
-		tag := fmtStr(false, level, levelFormat, appName,
-			v.packageName, -1, "", "%[2]s-%[3]s")
-		sl, err := syslog.New(syslog.LOG_DEBUG, tag)
-		if err != nil {
-			err = spew.Errorf("Failed to connect to syslog: %v\n", err)
-			return nil, err
-		}
-		v.syslog = sl
+func (v *PackageLogger) appName() string {
+	appName := v.parent.GetApplicationName()
+	if appName == "" {
+		appName = os.Args[0]
 	}
-	return v.syslog, nil
+	return appName
 }
 
-func (v *PackageLogger) writeToSyslog(level LogLevel,
-	levelFormat LevelFormat, appName string, msg string) error {
-	sl, err := v.getSyslog(level, levelFormat, appName)
-	if err != nil {
-		return err
-	}
-	switch level {
-	case DebugLevel:
-		return sl.Debug(msg)
-	case InfoLevel:
-		return sl.Info(msg)
-	case WarnLevel:
-		return sl.Warning(msg)
-	case ErrorLevel:
-		return sl.Err(msg)
-	case PanicLevel:
-		return sl.Crit(msg)
-	default:
-		return sl.Debug(msg)
-	}
+func (v *PackageLogger) print(level LogLevel, msg string) {
+	v.printDepthFields(level, msg, nil, depthOfDirectCaller)
 }
 
-func (v *PackageLogger) print(level LogLevel, msg string) {
-	lvl := v.GetLogLevel()
-	if lvl >= level {
+// dispatch hands record to the async pipeline (or delivers it synchronously
+// if async mode is not enabled). Panic-level messages are always delivered
+// synchronously, before the panic() call, so the crash log is not lost to a
+// still-draining async buffer.
+func (v *PackageLogger) dispatch(record Record) {
+	rec := &logRecord{pl: v, record: record}
+	if record.Level == PanicLevel {
+		v.deliver(rec)
 		levelFormat := v.parent.GetLevelFormat()
 		packagePrintLen := v.parent.GetPackagePrintLength()
-		appName := v.parent.GetApplicationName()
-		if appName == "" {
-			appName = os.Args[0]
-		}
-		out1 := fmtStr(true, level, levelFormat, appName,
-			v.packageName, packagePrintLen, msg, "%[1]s [%[3]s] %[4]s  %[5]s")
-		// File output
-		if lf := v.parent.GetLogFileInfo(); lf != nil {
-			rotateMaxSize := v.parent.GetRotateMaxSize()
-			rotateMaxCount := v.parent.GetRotateMaxCount()
-			out2 := fmtStr(false, level, levelFormat, appName,
-				v.packageName, packagePrintLen, msg, "%[1]s [%[3]s] %[4]s  %[5]s")
-			if err := lf.writeToFile(out2, rotateMaxSize, rotateMaxCount); err != nil {
-				err = spew.Errorf("Failed to report syslog message %q: %v\n", out2, err)
-				v.parent.log.Fatal(err)
-			}
-		}
-		// Syslog output
-		if v.parent.GetSyslogEnabled() {
-			if err := v.writeToSyslog(level, levelFormat, appName, msg); err != nil {
-				err = spew.Errorf("Failed to report syslog message %q: %v\n", msg, err)
-				v.parent.log.Fatal(err)
-			}
+		panic(renderLine(true, record, levelFormat, packagePrintLen))
+	}
+	v.parent.enqueue(rec)
+}
+
+// deliver fans a record out to every sink registered on the parent Logger.
+// It is called either directly by dispatch (synchronous mode) or by the
+// async pipeline's drain goroutine (see EnableAsync).
+func (v *PackageLogger) deliver(rec *logRecord) {
+	for _, sink := range v.parent.getSinks() {
+		if !sinkAcceptsLevel(sink, rec.record.Level) {
+			continue
 		}
-		// Console output
-		v.parent.log.Print(out1 + fmt.Sprintln())
-		// Check panic event
-		if level == PanicLevel {
-			panic(out1)
+		if err := sink.Write(rec.record); err != nil {
+			err = spew.Errorf("Failed to write to sink %q: %v\n", sink.Name(), err)
+			v.parent.log.Fatal(err)
 		}
 	}
 }
@@ -429,6 +498,38 @@ func EnableSyslog(enable bool) {
 	lgr.EnableSyslog(enable)
 }
 
+func SetVerbosity(level int) {
+	lgr.SetVerbosity(level)
+}
+
+func SetVModule(spec string) error {
+	return lgr.SetVModule(spec)
+}
+
+func EnableAsync(bufferSize int) {
+	lgr.EnableAsync(bufferSize)
+}
+
+func Flush(ctx context.Context) error {
+	return lgr.Flush(ctx)
+}
+
+func SetTimeRotation(interval time.Duration, maxAge time.Duration) {
+	lgr.SetTimeRotation(interval, maxAge)
+}
+
+func SetGzipLevel(level int) {
+	lgr.SetGzipLevel(level)
+}
+
+func EnableSourceLocation(enable bool) {
+	lgr.EnableSourceLocation(enable)
+}
+
+func SetBacktraceAt(locations ...string) error {
+	return lgr.SetBacktraceAt(locations...)
+}
+
 func FinalizeLogger() error {
 	var err error
 	if lgr != nil {
@@ -0,0 +1,225 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"sync"
+	"time"
+)
+
+// Record is the data passed to a Sink for a single log event.
+type Record struct {
+	Time    time.Time
+	Level   LogLevel
+	App     string
+	Package string
+	Msg     string
+	Fields  map[string]interface{}
+}
+
+// Sink receives formatted log records. A Logger fans every record out to
+// its registered sinks (see Logger.AddSink/RemoveSink); built-in sinks cover
+// console, file, syslog and line-delimited JSON output.
+type Sink interface {
+	Name() string
+	Write(record Record) error
+	Levels() []LogLevel
+	Close() error
+}
+
+var allLevels = []LogLevel{PanicLevel, ErrorLevel, WarnLevel, InfoLevel, DebugLevel}
+
+func sinkAcceptsLevel(sink Sink, level LogLevel) bool {
+	for _, l := range sink.Levels() {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+func renderLine(colored bool, record Record, levelFormat LevelFormat, packagePrintLen int) string {
+	return fmtStr(colored, record.Level, levelFormat, record.App,
+		record.Package, packagePrintLen, record.Msg, "%[1]s [%[3]s] %[4]s  %[5]s")
+}
+
+// ConsoleSink writes human-readable, ANSI-colored lines to the
+// Logger's underlying *log.Logger (stdout by default).
+type ConsoleSink struct {
+	logger *Logger
+}
+
+func NewConsoleSink(logger *Logger) *ConsoleSink {
+	return &ConsoleSink{logger: logger}
+}
+
+func (s *ConsoleSink) Name() string {
+	return "console"
+}
+
+func (s *ConsoleSink) Levels() []LogLevel {
+	return allLevels
+}
+
+func (s *ConsoleSink) Close() error {
+	return nil
+}
+
+func (s *ConsoleSink) Write(record Record) error {
+	out := renderLine(true, record, s.logger.GetLevelFormat(), s.logger.GetPackagePrintLength())
+	s.logger.log.Print(out + fmt.Sprintln())
+	return nil
+}
+
+// FileSink writes plain-text lines to a rotated LogFile, reusing the
+// Logger's configured size/time rotation parameters.
+type FileSink struct {
+	logger *Logger
+	file   *LogFile
+}
+
+func NewFileSink(logger *Logger, file *LogFile) *FileSink {
+	return &FileSink{logger: logger, file: file}
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Levels() []LogLevel {
+	return allLevels
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+func (s *FileSink) Write(record Record) error {
+	out := renderLine(false, record, s.logger.GetLevelFormat(), s.logger.GetPackagePrintLength())
+	return s.file.writeToFile(out, s.logger.GetRotateMaxSize(), s.logger.GetRotateMaxCount())
+}
+
+// SyslogSink writes to syslog, lazily opening one *syslog.Writer per
+// package name; each writer's tag is "<app>-<level>", built from the first
+// record written for that package.
+type SyslogSink struct {
+	logger  *Logger
+	mu      sync.Mutex
+	writers map[string]*syslog.Writer
+}
+
+func NewSyslogSink(logger *Logger) *SyslogSink {
+	return &SyslogSink{logger: logger, writers: make(map[string]*syslog.Writer)}
+}
+
+func (s *SyslogSink) Name() string {
+	return "syslog"
+}
+
+func (s *SyslogSink) Levels() []LogLevel {
+	return allLevels
+}
+
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	for name, w := range s.writers {
+		if e := w.Close(); e != nil {
+			err = e
+		}
+		delete(s.writers, name)
+	}
+	return err
+}
+
+func (s *SyslogSink) getWriter(record Record) (*syslog.Writer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w, ok := s.writers[record.Package]; ok {
+		return w, nil
+	}
+	tag := fmtStr(false, record.Level, s.logger.GetLevelFormat(), record.App,
+		record.Package, -1, "", "%[2]s-%[3]s")
+	w, err := syslog.New(syslog.LOG_DEBUG, tag)
+	if err != nil {
+		return nil, err
+	}
+	s.writers[record.Package] = w
+	return w, nil
+}
+
+func (s *SyslogSink) Write(record Record) error {
+	w, err := s.getWriter(record)
+	if err != nil {
+		return err
+	}
+	switch record.Level {
+	case DebugLevel:
+		return w.Debug(record.Msg)
+	case InfoLevel:
+		return w.Info(record.Msg)
+	case WarnLevel:
+		return w.Warning(record.Msg)
+	case ErrorLevel:
+		return w.Err(record.Msg)
+	case PanicLevel:
+		return w.Crit(record.Msg)
+	default:
+		return w.Debug(record.Msg)
+	}
+}
+
+// JSONSink emits one JSON object per line, suitable for shipping to
+// structured-logging pipelines such as fluentd or loki.
+type JSONSink struct {
+	out io.Writer
+	mu  sync.Mutex
+}
+
+func NewJSONSink(out io.Writer) *JSONSink {
+	return &JSONSink{out: out}
+}
+
+func (s *JSONSink) Name() string {
+	return "json"
+}
+
+func (s *JSONSink) Levels() []LogLevel {
+	return allLevels
+}
+
+func (s *JSONSink) Close() error {
+	return nil
+}
+
+type jsonRecord struct {
+	Ts      string                 `json:"ts"`
+	Level   string                 `json:"level"`
+	App     string                 `json:"app"`
+	Package string                 `json:"package"`
+	Msg     string                 `json:"msg"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+func (s *JSONSink) Write(record Record) error {
+	line := jsonRecord{
+		Ts:      record.Time.Format(time.RFC3339Nano),
+		Level:   record.Level.ShortStr(),
+		App:     record.App,
+		Package: record.Package,
+		Msg:     record.Msg,
+		Fields:  record.Fields,
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.out.Write(data)
+	return err
+}
@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Verbosity is a glog-style verbosity level. Higher values are more verbose.
+type Verbosity int32
+
+// vmoduleRule is a single "pattern=level" entry parsed from a -vmodule spec.
+// Pattern is matched either against the bare package name, or - when it
+// contains a "/" - against the full source file path of the caller.
+type vmoduleRule struct {
+	pattern  string
+	matchAll bool
+	re       *regexp.Regexp
+	level    Verbosity
+}
+
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			if strings.ContainsRune(`\.+()|[]{}^$`, r) {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func parseVModule(spec string) ([]vmoduleRule, error) {
+	var rules []vmoduleRule
+	if spec == "" {
+		return rules, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("vmodule entry %q is not in pattern=level form", entry)
+		}
+		level, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("vmodule entry %q has invalid level: %v", entry, err)
+		}
+		pattern := strings.TrimSpace(parts[0])
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("vmodule entry %q has invalid pattern: %v", entry, err)
+		}
+		rules = append(rules, vmoduleRule{pattern: pattern, matchAll: strings.Contains(pattern, "/"),
+			re: re, level: Verbosity(level)})
+	}
+	return rules, nil
+}
+
+// SetVerbosity sets the global verbosity threshold used by V().
+func (v *Logger) SetVerbosity(level int) {
+	atomic.StoreInt32(&v.verbosity, int32(level))
+}
+
+// GetVerbosity returns the current global verbosity threshold.
+func (v *Logger) GetVerbosity() int {
+	return int(atomic.LoadInt32(&v.verbosity))
+}
+
+// SetVModule configures per-file/per-package verbosity overrides, in the
+// same comma-separated "pattern=level" form as glog's -vmodule flag.
+// Patterns support glob syntax (* and ?) and are matched against the bare
+// package name, or - when the pattern contains a "/" - against the full
+// source file path of the caller. Calling SetVModule invalidates the
+// per-callsite decision cache built up by V().
+func (v *Logger) SetVModule(spec string) error {
+	rules, err := parseVModule(spec)
+	if err != nil {
+		return err
+	}
+	v.Lock()
+	v.vmodule = rules
+	v.Unlock()
+	v.vCache.Store(&sync.Map{})
+	return nil
+}
+
+func (v *Logger) vCacheMap() *sync.Map {
+	m, _ := v.vCache.Load().(*sync.Map)
+	if m == nil {
+		m = &sync.Map{}
+		v.vCache.Store(m)
+	}
+	return m
+}
+
+func (v *Logger) vModuleLevel(packageName, file string) (Verbosity, bool) {
+	v.RLock()
+	rules := v.vmodule
+	v.RUnlock()
+	var (
+		best  Verbosity
+		found bool
+	)
+	for _, rule := range rules {
+		subject := packageName
+		if rule.matchAll {
+			subject = file
+		}
+		if rule.re.MatchString(subject) {
+			if !found || rule.level > best {
+				best = rule.level
+				found = true
+			}
+		}
+	}
+	return best, found
+}
+
+// V reports whether logging at the given verbosity level is enabled for the
+// caller of V, either because the global verbosity is >= level, or because a
+// -vmodule entry matching the caller's package or file is >= level. The
+// per-callsite decision is cached by program counter so that repeated calls
+// from the same call site cost a runtime.Caller lookup plus a map lookup
+// instead of re-matching every vmodule rule.
+func (v *PackageLogger) V(level Verbosity) bool {
+	parent := v.parent
+	if Verbosity(parent.GetVerbosity()) >= level {
+		return true
+	}
+	pc, file, _, ok := runtime.Caller(1)
+	if !ok {
+		return false
+	}
+	cache := parent.vCacheMap()
+	if cached, ok := cache.Load(pc); ok {
+		return cached.(Verbosity) >= level
+	}
+	maxLevel, _ := parent.vModuleLevel(v.packageName, file)
+	cache.Store(pc, maxLevel)
+	return maxLevel >= level
+}
+
+// Vf logs a formatted Debug-level message if V(level) is enabled for the
+// caller, allowing callers to skip formatting expensive arguments otherwise.
+func (v *PackageLogger) Vf(level Verbosity, format string, args ...interface{}) {
+	pc, file, _, ok := runtime.Caller(1)
+	if !ok {
+		v.Debugf(format, args...)
+		return
+	}
+	enabled := Verbosity(v.parent.GetVerbosity()) >= level
+	if !enabled {
+		cache := v.parent.vCacheMap()
+		if cached, ok := cache.Load(pc); ok {
+			enabled = cached.(Verbosity) >= level
+		} else {
+			maxLevel, _ := v.parent.vModuleLevel(v.packageName, file)
+			cache.Store(pc, maxLevel)
+			enabled = maxLevel >= level
+		}
+	}
+	if enabled {
+		v.Debugf(format, args...)
+	}
+}